@@ -0,0 +1,92 @@
+// Package apierror provides a single typed error shape for HTTP handlers,
+// so every endpoint returns the same {error: {code, message, detail?}} body
+// and status-code mapping instead of hand-rolling fiber.Map responses.
+package apierror
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Error is returned by handlers in place of a raw fiber.Map response. Code
+// is the stable machine-readable string clients should match on; Message is
+// a human-readable default derived from Code unless overridden; Cause is
+// logged server-side but never sent to the client.
+type Error struct {
+	Code    string
+	Status  int
+	Message string
+	Detail  any
+	Cause   error
+}
+
+func (e *Error) Error() string {
+	if e.Cause != nil {
+		return e.Code + ": " + e.Cause.Error()
+	}
+	return e.Code
+}
+
+func (e *Error) Unwrap() error { return e.Cause }
+
+func newError(code string, status int) *Error {
+	return &Error{Code: code, Status: status, Message: code}
+}
+
+func BadRequest(code string) *Error         { return newError(code, fiber.StatusBadRequest) }
+func Unauthorized(code string) *Error       { return newError(code, fiber.StatusUnauthorized) }
+func Forbidden(code string) *Error          { return newError(code, fiber.StatusForbidden) }
+func NotFound(code string) *Error           { return newError(code, fiber.StatusNotFound) }
+func Conflict(code string) *Error           { return newError(code, fiber.StatusConflict) }
+func PreconditionFailed(code string) *Error { return newError(code, fiber.StatusPreconditionFailed) }
+func TooManyRequests(code string) *Error    { return newError(code, fiber.StatusTooManyRequests) }
+func BadGateway(code string) *Error         { return newError(code, fiber.StatusBadGateway) }
+func ServiceUnavailable(code string) *Error { return newError(code, fiber.StatusServiceUnavailable) }
+func Internal(code string) *Error           { return newError(code, fiber.StatusInternalServerError) }
+
+// WithCause attaches the underlying error for server-side logging. It is
+// never serialized to the client.
+func (e *Error) WithCause(err error) *Error {
+	e.Cause = err
+	return e
+}
+
+// WithDetail attaches machine-readable extra context (e.g. validation field
+// errors) that is safe to expose to the client.
+func (e *Error) WithDetail(detail any) *Error {
+	e.Detail = detail
+	return e
+}
+
+// WithMessage overrides the default human-readable message.
+func (e *Error) WithMessage(message string) *Error {
+	e.Message = message
+	return e
+}
+
+// Handler is installed as the Fiber app's ErrorHandler so every handler in
+// the app can `return apierror.BadRequest("...")` (or any other error) and
+// get a consistent response shape.
+func Handler(c *fiber.Ctx, err error) error {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		if apiErr.Cause != nil {
+			slog.Error("request failed", "code", apiErr.Code, "status", apiErr.Status, "error", apiErr.Cause)
+		}
+		body := fiber.Map{"code": apiErr.Code, "message": apiErr.Message}
+		if apiErr.Detail != nil {
+			body["detail"] = apiErr.Detail
+		}
+		return c.Status(apiErr.Status).JSON(fiber.Map{"error": body})
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return c.Status(fiberErr.Code).JSON(fiber.Map{"error": fiber.Map{"code": "request_failed", "message": fiberErr.Message}})
+	}
+
+	slog.Error("unhandled request error", "error", err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fiber.Map{"code": "internal_error", "message": "internal server error"}})
+}
@@ -0,0 +1,123 @@
+// Package hal builds HAL-style hypermedia envelopes ({_links, _embedded})
+// so API consumers can follow resources instead of hardcoding URL templates.
+package hal
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/jagadeesh/grainlify/backend/internal/config"
+)
+
+// ContentType is the media type HAL responses are served with.
+const ContentType = "application/hal+json"
+
+type Link struct {
+	Href string `json:"href"`
+}
+
+// Builder builds canonical links rooted at the app's API base URL, so every
+// handler links resources the same way. It also keeps the frontend base URL
+// from cfg, but none of the links it builds use it.
+type Builder struct {
+	frontendBaseURL string
+	apiBaseURL      string
+}
+
+func New(cfg config.Config) *Builder {
+	return &Builder{
+		frontendBaseURL: strings.TrimRight(cfg.FrontendBaseURL, "/"),
+		apiBaseURL:      strings.TrimRight(cfg.APIBaseURL, "/"),
+	}
+}
+
+func (b *Builder) ProjectLink(projectID uuid.UUID) Link {
+	return Link{Href: fmt.Sprintf("%s/projects/%s", b.apiBaseURL, projectID)}
+}
+
+func (b *Builder) IssueLink(projectID uuid.UUID, issueNumber int) Link {
+	return Link{Href: fmt.Sprintf("%s/projects/%s/issues/%d", b.apiBaseURL, projectID, issueNumber)}
+}
+
+func (b *Builder) ApplicationsLink(projectID uuid.UUID, issueNumber int) Link {
+	return Link{Href: fmt.Sprintf("%s/projects/%s/issues/%d/applications", b.apiBaseURL, projectID, issueNumber)}
+}
+
+func (b *Builder) ApplicationLink(projectID uuid.UUID, issueNumber int, applicationID uuid.UUID) Link {
+	return Link{Href: fmt.Sprintf("%s/projects/%s/issues/%d/applications/%s", b.apiBaseURL, projectID, issueNumber, applicationID)}
+}
+
+func (b *Builder) ApplicantLink(login string) Link {
+	return Link{Href: fmt.Sprintf("https://github.com/%s", login)}
+}
+
+// GitHubIssueLink wraps an external html_url as-is; it's already absolute.
+func (b *Builder) GitHubIssueLink(htmlURL string) Link {
+	return Link{Href: htmlURL}
+}
+
+// ApplicationEnvelope builds the HAL response for a single application:
+// the result of Apply, or one entry in the applications listing. self points
+// at the specific application resource, not the issue's applications collection.
+func (b *Builder) ApplicationEnvelope(projectID uuid.UUID, issueNumber int, applicationID uuid.UUID, status, applicantLogin, githubIssueURL string, comment fiber.Map) fiber.Map {
+	return fiber.Map{
+		"id":     applicationID,
+		"status": status,
+		"_links": fiber.Map{
+			"self":         b.ApplicationLink(projectID, issueNumber, applicationID),
+			"issue":        b.IssueLink(projectID, issueNumber),
+			"project":      b.ProjectLink(projectID),
+			"applicant":    b.ApplicantLink(applicantLogin),
+			"github_issue": b.GitHubIssueLink(githubIssueURL),
+		},
+		"_embedded": fiber.Map{
+			"comment": comment,
+		},
+	}
+}
+
+// CommentEnvelope builds the HAL response for a bot comment, which has no
+// applicant (it's posted by a maintainer, not an applicant).
+func (b *Builder) CommentEnvelope(projectID uuid.UUID, issueNumber int, githubIssueURL string, comment fiber.Map) fiber.Map {
+	return fiber.Map{
+		"_links": fiber.Map{
+			"self":         b.IssueLink(projectID, issueNumber),
+			"issue":        b.IssueLink(projectID, issueNumber),
+			"project":      b.ProjectLink(projectID),
+			"github_issue": b.GitHubIssueLink(githubIssueURL),
+		},
+		"_embedded": fiber.Map{
+			"comment": comment,
+		},
+	}
+}
+
+// ApplicationsCollectionEnvelope builds the HAL response for the list of
+// applications on an issue.
+func (b *Builder) ApplicationsCollectionEnvelope(projectID uuid.UUID, issueNumber int, applications []fiber.Map) fiber.Map {
+	return fiber.Map{
+		"_links": fiber.Map{
+			"self":    b.ApplicationsLink(projectID, issueNumber),
+			"issue":   b.IssueLink(projectID, issueNumber),
+			"project": b.ProjectLink(projectID),
+		},
+		"_embedded": fiber.Map{
+			"applications": applications,
+		},
+	}
+}
+
+// Write marshals envelope as application/hal+json.
+func (b *Builder) Write(c *fiber.Ctx, status int, envelope fiber.Map) error {
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+	c.Status(status)
+	c.Set(fiber.HeaderContentType, ContentType)
+	return c.Send(body)
+}
@@ -0,0 +1,353 @@
+package handlers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/jagadeesh/grainlify/backend/internal/apierror"
+	"github.com/jagadeesh/grainlify/backend/internal/config"
+	"github.com/jagadeesh/grainlify/backend/internal/db"
+	"github.com/jagadeesh/grainlify/backend/internal/notifications"
+)
+
+// GitHubWebhookHandler receives GitHub App webhook deliveries and keeps
+// github_issues (and related tables) in sync with GitHub's view of the
+// world, instead of relying on the app's own writes and periodic re-sync.
+type GitHubWebhookHandler struct {
+	cfg config.Config
+	db  *db.DB
+}
+
+func NewGitHubWebhookHandler(cfg config.Config, d *db.DB) *GitHubWebhookHandler {
+	return &GitHubWebhookHandler{cfg: cfg, db: d}
+}
+
+type webhookUser struct {
+	Login string `json:"login"`
+}
+
+type webhookIssue struct {
+	Number    int           `json:"number"`
+	State     string        `json:"state"`
+	HTMLURL   string        `json:"html_url"`
+	UpdatedAt string        `json:"updated_at"`
+	User      webhookUser   `json:"user"`
+	Assignees []webhookUser `json:"assignees"`
+}
+
+type webhookComment struct {
+	ID        int64       `json:"id"`
+	Body      string      `json:"body"`
+	HTMLURL   string      `json:"html_url"`
+	User      webhookUser `json:"user"`
+	CreatedAt string      `json:"created_at"`
+	UpdatedAt string      `json:"updated_at"`
+}
+
+type webhookRepository struct {
+	FullName string `json:"full_name"`
+}
+
+type webhookInstallation struct {
+	ID int64 `json:"id"`
+}
+
+type githubIssuesEventPayload struct {
+	Action       string              `json:"action"`
+	Issue        webhookIssue        `json:"issue"`
+	Repository   webhookRepository   `json:"repository"`
+	Installation webhookInstallation `json:"installation"`
+}
+
+type githubIssueCommentEventPayload struct {
+	Action       string              `json:"action"`
+	Issue        webhookIssue        `json:"issue"`
+	Comment      webhookComment      `json:"comment"`
+	Repository   webhookRepository   `json:"repository"`
+	Installation webhookInstallation `json:"installation"`
+}
+
+type githubInstallationEventPayload struct {
+	Action       string              `json:"action"`
+	Installation webhookInstallation `json:"installation"`
+}
+
+// Handle verifies the webhook signature, dispatches on X-GitHub-Event, and
+// upserts the affected github_issues row transactionally so the rest of the
+// app can trust DB state instead of racing with GitHub.
+func (h *GitHubWebhookHandler) Handle() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if h.db == nil || h.db.Pool == nil {
+			return apierror.ServiceUnavailable("db_not_configured")
+		}
+		if strings.TrimSpace(h.cfg.GitHubWebhookSecret) == "" {
+			return apierror.ServiceUnavailable("webhook_secret_not_configured")
+		}
+
+		body := c.Body()
+		sig := c.Get("X-Hub-Signature-256")
+		if !verifyGitHubSignature(h.cfg.GitHubWebhookSecret, sig, body) {
+			return apierror.Unauthorized("invalid_signature")
+		}
+
+		event := c.Get("X-GitHub-Event")
+		var err error
+		switch event {
+		case "issues":
+			err = h.handleIssuesEvent(c, body)
+		case "issue_comment":
+			err = h.handleIssueCommentEvent(c, body)
+		case "installation":
+			err = h.handleInstallationEvent(c, body)
+		case "installation_repositories":
+			err = h.handleInstallationRepositoriesEvent(c, body)
+		default:
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{"ok": true, "ignored": event})
+		}
+		if err != nil {
+			return apierror.Internal("webhook_processing_failed").WithCause(err).WithDetail(fiber.Map{"event": event})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"ok": true})
+	}
+}
+
+func verifyGitHubSignature(secret, header string, body []byte) bool {
+	const prefix = "sha256="
+	if !strings.HasPrefix(header, prefix) {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(strings.TrimPrefix(header, prefix)))
+}
+
+func (h *GitHubWebhookHandler) handleIssuesEvent(c *fiber.Ctx, body []byte) error {
+	var payload githubIssuesEventPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return err
+	}
+
+	switch payload.Action {
+	case "opened", "edited", "closed", "reopened", "assigned", "unassigned":
+	default:
+		return nil
+	}
+
+	assigneesJSON, err := json.Marshal(payload.Issue.Assignees)
+	if err != nil {
+		return err
+	}
+
+	installationID := strconv.FormatInt(payload.Installation.ID, 10)
+
+	ctx := c.Context()
+	tx, err := h.db.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var projectID string
+	if err := tx.QueryRow(ctx, `
+SELECT id FROM projects
+WHERE github_app_installation_id = $1 AND github_full_name = $2 AND deleted_at IS NULL
+`, installationID, payload.Repository.FullName).Scan(&projectID); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil
+		}
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `
+INSERT INTO github_issues (project_id, number, state, author_login, assignees, url, updated_at_github, last_seen_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, now())
+ON CONFLICT (project_id, number) DO UPDATE
+SET state = EXCLUDED.state,
+    assignees = EXCLUDED.assignees,
+    updated_at_github = EXCLUDED.updated_at_github,
+    last_seen_at = now()
+`, projectID, payload.Issue.Number, payload.Issue.State, payload.Issue.User.Login, assigneesJSON, payload.Issue.HTMLURL, payload.Issue.UpdatedAt); err != nil {
+		return err
+	}
+
+	if payload.Action == "assigned" {
+		if err := h.notifyPriorApplicantAssigned(ctx, tx, projectID, payload.Issue); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// notifyPriorApplicantAssigned looks for a pending issue_applications row
+// from one of the issue's current assignees, marks it accepted, and sends
+// the applicant an application_accepted notification.
+func (h *GitHubWebhookHandler) notifyPriorApplicantAssigned(ctx context.Context, tx pgx.Tx, projectID string, issue webhookIssue) error {
+	for _, assignee := range issue.Assignees {
+		var applicationID, applicantUserID uuid.UUID
+		err := tx.QueryRow(ctx, `
+SELECT ia.id, ia.user_id
+FROM issue_applications ia
+JOIN github_accounts ga ON ga.user_id = ia.user_id
+WHERE ia.project_id = $1 AND ia.issue_number = $2 AND ia.status = 'pending' AND ga.login = $3
+LIMIT 1
+`, projectID, issue.Number, assignee.Login).Scan(&applicationID, &applicantUserID)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				continue
+			}
+			return err
+		}
+
+		if _, err := tx.Exec(ctx, `
+UPDATE issue_applications SET status = 'accepted', updated_at = now() WHERE id = $1
+`, applicationID); err != nil {
+			return err
+		}
+
+		if err := notifications.Enqueue(ctx, tx, applicantUserID, notifications.KindApplicationAccepted, map[string]any{
+			"message":      fmt.Sprintf("Your application for issue #%d was accepted", issue.Number),
+			"project_id":   projectID,
+			"issue_number": issue.Number,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *GitHubWebhookHandler) handleIssueCommentEvent(c *fiber.Ctx, body []byte) error {
+	var payload githubIssueCommentEventPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return err
+	}
+
+	switch payload.Action {
+	case "created", "edited", "deleted":
+	default:
+		return nil
+	}
+
+	commentJSON, err := json.Marshal(payload.Comment)
+	if err != nil {
+		return err
+	}
+
+	installationID := strconv.FormatInt(payload.Installation.ID, 10)
+
+	ctx := c.Context()
+	tx, err := h.db.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var projectID string
+	if err := tx.QueryRow(ctx, `
+SELECT id FROM projects
+WHERE github_app_installation_id = $1 AND github_full_name = $2 AND deleted_at IS NULL
+`, installationID, payload.Repository.FullName).Scan(&projectID); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil
+		}
+		return err
+	}
+
+	switch payload.Action {
+	case "deleted":
+		// Filter the deleted comment out by ID instead of computing its array
+		// index: if it was never captured (e.g. predates this handler), the
+		// filter is a no-op rather than a NULL index that wipes the column.
+		if _, err := tx.Exec(ctx, `
+UPDATE github_issues
+SET comments = (
+    SELECT COALESCE(jsonb_agg(elem), '[]'::jsonb)
+    FROM jsonb_array_elements(COALESCE(comments, '[]'::jsonb)) elem
+    WHERE (elem->>'id')::bigint != $3
+),
+    comments_count = GREATEST(COALESCE(comments_count, 0) - (
+        SELECT count(*)
+        FROM jsonb_array_elements(COALESCE(comments, '[]'::jsonb)) elem
+        WHERE (elem->>'id')::bigint = $3
+    ), 0),
+    updated_at_github = $4,
+    last_seen_at = now()
+WHERE project_id = $1 AND number = $2
+`, projectID, payload.Issue.Number, payload.Comment.ID, payload.Comment.UpdatedAt); err != nil {
+			return err
+		}
+	default:
+		if _, err := tx.Exec(ctx, `
+UPDATE github_issues
+SET comments = (
+    SELECT COALESCE(jsonb_agg(elem), '[]'::jsonb)
+    FROM jsonb_array_elements(COALESCE(comments, '[]'::jsonb)) elem
+    WHERE (elem->>'id')::bigint != $3
+) || $4::jsonb,
+    comments_count = COALESCE(comments_count, 0) + CASE WHEN $5 = 'created' THEN 1 ELSE 0 END,
+    updated_at_github = $6,
+    last_seen_at = now()
+WHERE project_id = $1 AND number = $2
+`, projectID, payload.Issue.Number, payload.Comment.ID, commentJSON, payload.Action, payload.Comment.UpdatedAt); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (h *GitHubWebhookHandler) handleInstallationEvent(c *fiber.Ctx, body []byte) error {
+	var payload githubInstallationEventPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return err
+	}
+
+	if payload.Action != "deleted" {
+		return nil
+	}
+
+	_, err := h.db.Pool.Exec(c.Context(), `
+UPDATE projects SET github_app_installation_id = NULL
+WHERE github_app_installation_id = $1
+`, strconv.FormatInt(payload.Installation.ID, 10))
+	return err
+}
+
+func (h *GitHubWebhookHandler) handleInstallationRepositoriesEvent(c *fiber.Ctx, body []byte) error {
+	var payload struct {
+		Action              string              `json:"action"`
+		Installation        webhookInstallation `json:"installation"`
+		RepositoriesRemoved []webhookRepository `json:"repositories_removed"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return err
+	}
+	if payload.Action != "removed" || len(payload.RepositoriesRemoved) == 0 {
+		return nil
+	}
+
+	installationID := strconv.FormatInt(payload.Installation.ID, 10)
+
+	ctx := c.Context()
+	for _, repo := range payload.RepositoriesRemoved {
+		if _, err := h.db.Pool.Exec(ctx, `
+UPDATE projects SET github_app_installation_id = NULL
+WHERE github_app_installation_id = $1 AND github_full_name = $2
+`, installationID, repo.FullName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func sign(t *testing.T, secret string, body []byte) string {
+	t.Helper()
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyGitHubSignature(t *testing.T) {
+	secret := "whsec_test"
+	body := []byte(`{"action":"opened"}`)
+	validHeader := sign(t, secret, body)
+
+	cases := []struct {
+		name   string
+		secret string
+		header string
+		body   []byte
+		want   bool
+	}{
+		{"valid signature", secret, validHeader, body, true},
+		{"wrong secret", "whsec_other", validHeader, body, false},
+		{"tampered body", secret, validHeader, []byte(`{"action":"closed"}`), false},
+		{"missing prefix", secret, hex.EncodeToString([]byte("deadbeef")), body, false},
+		{"empty header", secret, "", body, false},
+		{"malformed hex after prefix", secret, "sha256=not-hex", body, false},
+		{"empty body still verifies", secret, sign(t, secret, nil), nil, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := verifyGitHubSignature(tc.secret, tc.header, tc.body)
+			if got != tc.want {
+				t.Errorf("verifyGitHubSignature(%q, %q, %q) = %v, want %v", tc.secret, tc.header, tc.body, got, tc.want)
+			}
+		})
+	}
+}
+
+// withTestCtx runs fn with a real *fiber.Ctx obtained from a throwaway
+// request, since fiber.Ctx has no public constructor outside of serving one.
+func withTestCtx(t *testing.T, fn func(c *fiber.Ctx) error) error {
+	t.Helper()
+	app := fiber.New()
+	var got error
+	app.Post("/webhook", func(c *fiber.Ctx) error {
+		got = fn(c)
+		return nil
+	})
+	req := httptest.NewRequest("POST", "/webhook", bytes.NewReader(nil))
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	return got
+}
+
+// The event handlers below filter on payload.Action before ever touching the
+// DB, so a handler with a nil *db.DB still runs safely as long as the action
+// is one they ignore. Reaching the end of these without a nil-pointer panic
+// proves the filter runs first. Exercising the upsert SQL itself needs a
+// real or mocked pool, which this dependency-free snapshot (no go.mod/go.sum,
+// no pgxmock) has no harness for yet.
+
+func TestHandleIssuesEvent_IgnoresUnhandledAction(t *testing.T) {
+	h := &GitHubWebhookHandler{}
+	body := []byte(`{"action":"labeled","issue":{"number":1},"repository":{"full_name":"o/r"},"installation":{"id":1}}`)
+	err := withTestCtx(t, func(c *fiber.Ctx) error {
+		return h.handleIssuesEvent(c, body)
+	})
+	if err != nil {
+		t.Errorf("expected nil error for ignored action, got %v", err)
+	}
+}
+
+func TestHandleIssueCommentEvent_IgnoresUnhandledAction(t *testing.T) {
+	h := &GitHubWebhookHandler{}
+	body := []byte(`{"action":"dismissed","issue":{"number":1},"comment":{"id":1},"repository":{"full_name":"o/r"},"installation":{"id":1}}`)
+	err := withTestCtx(t, func(c *fiber.Ctx) error {
+		return h.handleIssueCommentEvent(c, body)
+	})
+	if err != nil {
+		t.Errorf("expected nil error for ignored action, got %v", err)
+	}
+}
+
+func TestHandleInstallationEvent_IgnoresNonDeletedAction(t *testing.T) {
+	h := &GitHubWebhookHandler{}
+	body := []byte(`{"action":"created","installation":{"id":1}}`)
+	err := withTestCtx(t, func(c *fiber.Ctx) error {
+		return h.handleInstallationEvent(c, body)
+	})
+	if err != nil {
+		t.Errorf("expected nil error for non-deleted action, got %v", err)
+	}
+}
+
+func TestHandleInstallationRepositoriesEvent_IgnoresAddedAction(t *testing.T) {
+	h := &GitHubWebhookHandler{}
+	body := []byte(`{"action":"added","installation":{"id":1},"repositories_removed":[]}`)
+	err := withTestCtx(t, func(c *fiber.Ctx) error {
+		return h.handleInstallationRepositoriesEvent(c, body)
+	})
+	if err != nil {
+		t.Errorf("expected nil error for added action, got %v", err)
+	}
+}
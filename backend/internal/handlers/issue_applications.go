@@ -10,15 +10,26 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 
+	"github.com/jagadeesh/grainlify/backend/internal/apierror"
 	"github.com/jagadeesh/grainlify/backend/internal/auth"
 	"github.com/jagadeesh/grainlify/backend/internal/config"
 	"github.com/jagadeesh/grainlify/backend/internal/db"
 	"github.com/jagadeesh/grainlify/backend/internal/github"
+	"github.com/jagadeesh/grainlify/backend/internal/hal"
+	"github.com/jagadeesh/grainlify/backend/internal/notifications"
 )
 
 const grainlifyApplicationPrefix = "[grainlify application]"
 
+const (
+	applicationStatusPending   = "pending"
+	applicationStatusAccepted  = "accepted"
+	applicationStatusWithdrawn = "withdrawn"
+	applicationStatusRejected  = "rejected"
+)
+
 type IssueApplicationsHandler struct {
 	cfg config.Config
 	db  *db.DB
@@ -35,42 +46,42 @@ type applyToIssueRequest struct {
 func (h *IssueApplicationsHandler) Apply() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		if h.db == nil || h.db.Pool == nil {
-			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "db_not_configured"})
+			return apierror.ServiceUnavailable("db_not_configured")
 		}
 		if strings.TrimSpace(h.cfg.TokenEncKeyB64) == "" {
-			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "token_encryption_not_configured"})
+			return apierror.ServiceUnavailable("token_encryption_not_configured")
 		}
 
 		projectID, err := uuid.Parse(c.Params("id"))
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid_project_id"})
+			return apierror.BadRequest("invalid_project_id")
 		}
 		issueNumber, err := c.ParamsInt("number")
 		if err != nil || issueNumber <= 0 {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid_issue_number"})
+			return apierror.BadRequest("invalid_issue_number")
 		}
 
 		userIDStr, _ := c.Locals(auth.LocalUserID).(string)
 		userID, err := uuid.Parse(userIDStr)
 		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "invalid_user"})
+			return apierror.Unauthorized("invalid_user")
 		}
 
 		var req applyToIssueRequest
 		if err := c.BodyParser(&req); err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid_body"})
+			return apierror.BadRequest("invalid_body").WithCause(err)
 		}
 		req.Message = strings.TrimSpace(req.Message)
 		if req.Message == "" {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "message_required"})
+			return apierror.BadRequest("message_required")
 		}
 		if len(req.Message) > 5000 {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "message_too_long"})
+			return apierror.BadRequest("message_too_long")
 		}
 
 		linked, err := github.GetLinkedAccount(c.Context(), h.db.Pool, userID, h.cfg.TokenEncKeyB64)
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "github_not_linked"})
+			return apierror.PreconditionFailed("github_not_linked").WithCause(err)
 		}
 
 		// Load repo + issue state + optional app installation and issue URL from DB.
@@ -79,29 +90,56 @@ func (h *IssueApplicationsHandler) Apply() fiber.Handler {
 		var authorLogin string
 		var assigneesJSON []byte
 		var installationID *string
+		var ownerUserID uuid.UUID
 		if err := h.db.Pool.QueryRow(c.Context(), `
-SELECT p.github_full_name, p.github_app_installation_id, gi.state, gi.author_login, gi.assignees, COALESCE(gi.url, '')
+SELECT p.github_full_name, p.github_app_installation_id, gi.state, gi.author_login, gi.assignees, COALESCE(gi.url, ''), p.owner_user_id
 FROM projects p
 JOIN github_issues gi ON gi.project_id = p.id
 WHERE p.id = $1 AND p.status = 'verified' AND p.deleted_at IS NULL
   AND gi.number = $2
 LIMIT 1
-`, projectID, issueNumber).Scan(&fullName, &installationID, &state, &authorLogin, &assigneesJSON, &issueURL); err != nil {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "issue_not_found"})
+`, projectID, issueNumber).Scan(&fullName, &installationID, &state, &authorLogin, &assigneesJSON, &issueURL, &ownerUserID); err != nil {
+			return apierror.NotFound("issue_not_found").WithCause(err)
 		}
 
 		if strings.ToLower(strings.TrimSpace(state)) != "open" {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "issue_not_open"})
+			return apierror.BadRequest("issue_not_open")
 		}
 		if strings.EqualFold(strings.TrimSpace(authorLogin), strings.TrimSpace(linked.Login)) {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "cannot_apply_to_own_issue"})
+			return apierror.BadRequest("cannot_apply_to_own_issue")
 		}
 
 		// "yet to be assigned" => no assignees.
 		var assignees []any
 		_ = json.Unmarshal(assigneesJSON, &assignees)
 		if len(assignees) > 0 {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "issue_already_assigned"})
+			return apierror.Conflict("issue_already_assigned")
+		}
+
+		var existingPending bool
+		if err := h.db.Pool.QueryRow(c.Context(), `
+SELECT EXISTS (
+  SELECT 1 FROM issue_applications
+  WHERE project_id = $1 AND issue_number = $2 AND user_id = $3 AND status = 'pending'
+)
+`, projectID, issueNumber, userID).Scan(&existingPending); err != nil {
+			return apierror.Internal("application_lookup_failed").WithCause(err)
+		}
+		if existingPending {
+			return apierror.Conflict("application_exists")
+		}
+
+		if h.cfg.MaxApplicationsPerHour > 0 {
+			var recentCount int
+			if err := h.db.Pool.QueryRow(c.Context(), `
+SELECT count(*) FROM issue_applications
+WHERE user_id = $1 AND created_at > now() - interval '1 hour'
+`, userID).Scan(&recentCount); err != nil {
+				return apierror.Internal("application_lookup_failed").WithCause(err)
+			}
+			if recentCount >= h.cfg.MaxApplicationsPerHour {
+				return apierror.TooManyRequests("too_many_applications")
+			}
 		}
 
 		// Build Drips Wave–style template: header, blockquote for message, maintainer instructions with links.
@@ -119,7 +157,10 @@ LIMIT 1
 		gh := github.NewClient()
 
 		// Post as Grainlify bot when project has the app installed so GitHub shows "with Grainlify" (like Drips Wave).
+		// commentToken tracks whichever token actually created ghComment, so a
+		// later rollback can delete it with that same token instead of guessing.
 		var ghComment github.IssueComment
+		commentToken := linked.AccessToken
 		instID := ""
 		if installationID != nil {
 			instID = strings.TrimSpace(*installationID)
@@ -134,6 +175,8 @@ LIMIT 1
 						slog.Warn("failed to create github issue comment as bot for application, falling back to user",
 							"project_id", projectID.String(), "error", err)
 						ghComment, err = gh.CreateIssueComment(c.Context(), linked.AccessToken, fullName, issueNumber, commentBody)
+					} else {
+						commentToken = token
 					}
 				} else {
 					ghComment, err = gh.CreateIssueComment(c.Context(), linked.AccessToken, fullName, issueNumber, commentBody)
@@ -153,31 +196,91 @@ LIMIT 1
 				"github_login", linked.Login,
 				"error", err,
 			)
-			return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": "github_comment_create_failed"})
+			return apierror.BadGateway("github_comment_create_failed").WithCause(err)
 		}
 
-		// Persist the comment into our DB so maintainers see it immediately.
+		// Persist the application row, the comment, and the notification together.
+		// If the DB transaction fails to commit, delete the GitHub comment we just
+		// created so we don't leave a dangling application with no record of it.
 		commentJSON, _ := json.Marshal(ghComment)
-		_, _ = h.db.Pool.Exec(c.Context(), `
+		applicationID, err := h.persistApplication(c, projectID, issueNumber, userID, ownerUserID, req.Message, ghComment, commentJSON, linked.Login)
+		if err != nil {
+			slog.Warn("failed to persist application, rolling back github comment",
+				"project_id", projectID.String(), "issue_number", issueNumber, "error", err)
+			if delErr := gh.DeleteIssueComment(c.Context(), commentToken, fullName, ghComment.ID); delErr != nil {
+				slog.Error("failed to roll back github comment after application persist failure",
+					"project_id", projectID.String(), "issue_number", issueNumber, "comment_id", ghComment.ID, "error", delErr)
+			}
+			var apiErr *apierror.Error
+			if errors.As(err, &apiErr) {
+				return apiErr
+			}
+			return apierror.Internal("application_persist_failed").WithCause(err)
+		}
+
+		halBuilder := hal.New(h.cfg)
+		return halBuilder.Write(c, fiber.StatusOK, halBuilder.ApplicationEnvelope(projectID, issueNumber, applicationID, applicationStatusPending, linked.Login, issueURL, fiber.Map{
+			"id":         ghComment.ID,
+			"body":       ghComment.Body,
+			"user":       fiber.Map{"login": ghComment.User.Login},
+			"created_at": ghComment.CreatedAt,
+			"updated_at": ghComment.UpdatedAt,
+		}))
+	}
+}
+
+// persistApplication records the application, the GitHub comment, and the
+// owner notification in a single transaction. The caller deletes the GitHub
+// comment if this returns an error, since the comment already exists on
+// GitHub by the time this runs.
+func (h *IssueApplicationsHandler) persistApplication(c *fiber.Ctx, projectID uuid.UUID, issueNumber int, userID, ownerUserID uuid.UUID, message string, ghComment github.IssueComment, commentJSON []byte, applicantLogin string) (uuid.UUID, error) {
+	ctx := c.Context()
+	tx, err := h.db.Pool.Begin(ctx)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	const pgUniqueViolation = "23505"
+	var applicationID uuid.UUID
+	if err := tx.QueryRow(ctx, `
+INSERT INTO issue_applications (project_id, issue_number, user_id, message, github_comment_id, status)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id
+`, projectID, issueNumber, userID, message, ghComment.ID, applicationStatusPending).Scan(&applicationID); err != nil {
+		// Backstop for the SELECT EXISTS check above: a concurrent Apply can
+		// still race past it, so let the partial unique index be the final word.
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return uuid.UUID{}, apierror.Conflict("application_exists").WithCause(err)
+		}
+		return uuid.UUID{}, err
+	}
+
+	if _, err := tx.Exec(ctx, `
 UPDATE github_issues
 SET comments = COALESCE(comments, '[]'::jsonb) || $3::jsonb,
     comments_count = COALESCE(comments_count, 0) + 1,
     updated_at_github = $4,
     last_seen_at = now()
 WHERE project_id = $1 AND number = $2
-`, projectID, issueNumber, commentJSON, ghComment.UpdatedAt)
+`, projectID, issueNumber, commentJSON, ghComment.UpdatedAt); err != nil {
+		return uuid.UUID{}, err
+	}
+
+	if err := notifications.Enqueue(ctx, tx, ownerUserID, notifications.KindApplicationSubmitted, fiber.Map{
+		"message":      fmt.Sprintf("@%s applied to issue #%d", applicantLogin, issueNumber),
+		"project_id":   projectID,
+		"issue_number": issueNumber,
+		"applicant":    applicantLogin,
+	}); err != nil {
+		return uuid.UUID{}, err
+	}
 
-		return c.Status(fiber.StatusOK).JSON(fiber.Map{
-			"ok": true,
-			"comment": fiber.Map{
-				"id": ghComment.ID,
-				"body": ghComment.Body,
-				"user": fiber.Map{"login": ghComment.User.Login},
-				"created_at": ghComment.CreatedAt,
-				"updated_at": ghComment.UpdatedAt,
-			},
-		})
+	if err := tx.Commit(ctx); err != nil {
+		return uuid.UUID{}, err
 	}
+	return applicationID, nil
 }
 
 type botCommentRequest struct {
@@ -189,38 +292,38 @@ type botCommentRequest struct {
 func (h *IssueApplicationsHandler) PostBotComment() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		if h.db == nil || h.db.Pool == nil {
-			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "db_not_configured"})
+			return apierror.ServiceUnavailable("db_not_configured")
 		}
 		if strings.TrimSpace(h.cfg.GitHubAppID) == "" || strings.TrimSpace(h.cfg.GitHubAppPrivateKey) == "" {
-			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "github_app_not_configured"})
+			return apierror.ServiceUnavailable("github_app_not_configured")
 		}
 
 		projectID, err := uuid.Parse(c.Params("id"))
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid_project_id"})
+			return apierror.BadRequest("invalid_project_id")
 		}
 		issueNumber, err := c.ParamsInt("number")
 		if err != nil || issueNumber <= 0 {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid_issue_number"})
+			return apierror.BadRequest("invalid_issue_number")
 		}
 
 		userIDStr, _ := c.Locals(auth.LocalUserID).(string)
 		userID, err := uuid.Parse(userIDStr)
 		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "invalid_user"})
+			return apierror.Unauthorized("invalid_user")
 		}
 		role, _ := c.Locals(auth.LocalRole).(string)
 
 		var req botCommentRequest
 		if err := c.BodyParser(&req); err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid_body"})
+			return apierror.BadRequest("invalid_body").WithCause(err)
 		}
 		req.Body = strings.TrimSpace(req.Body)
 		if req.Body == "" {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "body_required"})
+			return apierror.BadRequest("body_required")
 		}
 		if len(req.Body) > 32000 {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "body_too_long"})
+			return apierror.BadRequest("body_too_long")
 		}
 
 		var owner uuid.UUID
@@ -231,22 +334,22 @@ FROM projects
 WHERE id = $1 AND status = 'verified' AND deleted_at IS NULL
 `, projectID).Scan(&owner, &fullName, &installationID)
 		if errors.Is(err, pgx.ErrNoRows) {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "project_not_found"})
+			return apierror.NotFound("project_not_found")
 		}
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "project_lookup_failed"})
+			return apierror.Internal("project_lookup_failed").WithCause(err)
 		}
 		if owner != userID && role != "admin" {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "forbidden"})
+			return apierror.Forbidden("forbidden")
 		}
 		if installationID == "" {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "project_has_no_github_app_installation"})
+			return apierror.BadRequest("project_has_no_github_app_installation")
 		}
 
 		appClient, err := github.NewGitHubAppClient(h.cfg.GitHubAppID, h.cfg.GitHubAppPrivateKey)
 		if err != nil {
 			slog.Error("failed to create GitHub App client for bot comment", "error", err)
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "github_app_client_failed"})
+			return apierror.Internal("github_app_client_failed").WithCause(err)
 		}
 		token, err := appClient.GetInstallationToken(c.Context(), installationID)
 		if err != nil {
@@ -255,7 +358,7 @@ WHERE id = $1 AND status = 'verified' AND deleted_at IS NULL
 				"installation_id", installationID,
 				"error", err,
 			)
-			return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": "installation_token_failed"})
+			return apierror.BadGateway("installation_token_failed").WithCause(err)
 		}
 
 		gh := github.NewClient()
@@ -267,7 +370,7 @@ WHERE id = $1 AND status = 'verified' AND deleted_at IS NULL
 				"github_full_name", fullName,
 				"error", err,
 			)
-			return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": "github_comment_create_failed"})
+			return apierror.BadGateway("github_comment_create_failed").WithCause(err)
 		}
 
 		commentJSON, _ := json.Marshal(ghComment)
@@ -280,17 +383,176 @@ SET comments = COALESCE(comments, '[]'::jsonb) || $3::jsonb,
 WHERE project_id = $1 AND number = $2
 `, projectID, issueNumber, commentJSON, ghComment.UpdatedAt)
 
-		return c.Status(fiber.StatusOK).JSON(fiber.Map{
-			"ok": true,
-			"comment": fiber.Map{
-				"id": ghComment.ID,
-				"body": ghComment.Body,
-				"user": fiber.Map{"login": ghComment.User.Login},
-				"created_at": ghComment.CreatedAt,
-				"updated_at": ghComment.UpdatedAt,
-			},
-		})
+		issueURL := fmt.Sprintf("https://github.com/%s/issues/%d", fullName, issueNumber)
+		halBuilder := hal.New(h.cfg)
+		return halBuilder.Write(c, fiber.StatusOK, halBuilder.CommentEnvelope(projectID, issueNumber, issueURL, fiber.Map{
+			"id":         ghComment.ID,
+			"body":       ghComment.Body,
+			"user":       fiber.Map{"login": ghComment.User.Login},
+			"created_at": ghComment.CreatedAt,
+			"updated_at": ghComment.UpdatedAt,
+		}))
 	}
 }
 
+// ListApplications returns the applications made on an issue from the
+// issue_applications table, embedding each application's GitHub comment.
+func (h *IssueApplicationsHandler) ListApplications() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if h.db == nil || h.db.Pool == nil {
+			return apierror.ServiceUnavailable("db_not_configured")
+		}
+
+		projectID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return apierror.BadRequest("invalid_project_id")
+		}
+		issueNumber, err := c.ParamsInt("number")
+		if err != nil || issueNumber <= 0 {
+			return apierror.BadRequest("invalid_issue_number")
+		}
 
+		userIDStr, _ := c.Locals(auth.LocalUserID).(string)
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return apierror.Unauthorized("invalid_user")
+		}
+		role, _ := c.Locals(auth.LocalRole).(string)
+
+		var owner uuid.UUID
+		var commentsJSON []byte
+		var issueURL, fullName string
+		if err := h.db.Pool.QueryRow(c.Context(), `
+SELECT gi.comments, COALESCE(gi.url, ''), p.github_full_name, p.owner_user_id
+FROM projects p
+JOIN github_issues gi ON gi.project_id = p.id
+WHERE p.id = $1 AND p.status = 'verified' AND p.deleted_at IS NULL
+  AND gi.number = $2
+LIMIT 1
+`, projectID, issueNumber).Scan(&commentsJSON, &issueURL, &fullName, &owner); err != nil {
+			return apierror.NotFound("issue_not_found").WithCause(err)
+		}
+		if owner != userID && role != "admin" {
+			return apierror.Forbidden("forbidden")
+		}
+		if issueURL == "" {
+			issueURL = fmt.Sprintf("https://github.com/%s/issues/%d", fullName, issueNumber)
+		}
+
+		var comments []github.IssueComment
+		_ = json.Unmarshal(commentsJSON, &comments)
+		commentByID := make(map[int64]github.IssueComment, len(comments))
+		for _, comment := range comments {
+			commentByID[comment.ID] = comment
+		}
+
+		rows, err := h.db.Pool.Query(c.Context(), `
+SELECT ia.id, ia.github_comment_id, ia.status, ga.login
+FROM issue_applications ia
+JOIN github_accounts ga ON ga.user_id = ia.user_id
+WHERE ia.project_id = $1 AND ia.issue_number = $2
+ORDER BY ia.created_at ASC
+`, projectID, issueNumber)
+		if err != nil {
+			return apierror.Internal("applications_query_failed").WithCause(err)
+		}
+		defer rows.Close()
+
+		halBuilder := hal.New(h.cfg)
+		applications := []fiber.Map{}
+		for rows.Next() {
+			var applicationID uuid.UUID
+			var githubCommentID int64
+			var status, login string
+			if err := rows.Scan(&applicationID, &githubCommentID, &status, &login); err != nil {
+				return apierror.Internal("applications_scan_failed").WithCause(err)
+			}
+			comment := commentByID[githubCommentID]
+			envelope := halBuilder.ApplicationEnvelope(projectID, issueNumber, applicationID, status, login, issueURL, fiber.Map{
+				"id":         comment.ID,
+				"body":       comment.Body,
+				"user":       fiber.Map{"login": login},
+				"created_at": comment.CreatedAt,
+				"updated_at": comment.UpdatedAt,
+			})
+			applications = append(applications, envelope)
+		}
+
+		return halBuilder.Write(c, fiber.StatusOK, halBuilder.ApplicationsCollectionEnvelope(projectID, issueNumber, applications))
+	}
+}
+
+type withdrawApplicationResponse struct {
+	OK bool `json:"ok"`
+}
+
+// Withdraw lets the applicant soft-withdraw their own pending application and
+// best-effort deletes the GitHub comment it posted.
+func (h *IssueApplicationsHandler) Withdraw() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if h.db == nil || h.db.Pool == nil {
+			return apierror.ServiceUnavailable("db_not_configured")
+		}
+
+		projectID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return apierror.BadRequest("invalid_project_id")
+		}
+		issueNumber, err := c.ParamsInt("number")
+		if err != nil || issueNumber <= 0 {
+			return apierror.BadRequest("invalid_issue_number")
+		}
+		applicationID, err := uuid.Parse(c.Params("appID"))
+		if err != nil {
+			return apierror.BadRequest("invalid_application_id")
+		}
+
+		userIDStr, _ := c.Locals(auth.LocalUserID).(string)
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return apierror.Unauthorized("invalid_user")
+		}
+
+		var applicantUserID uuid.UUID
+		var status string
+		var githubCommentID int64
+		if err := h.db.Pool.QueryRow(c.Context(), `
+SELECT user_id, status, github_comment_id
+FROM issue_applications
+WHERE id = $1 AND project_id = $2 AND issue_number = $3
+`, applicationID, projectID, issueNumber).Scan(&applicantUserID, &status, &githubCommentID); err != nil {
+			return apierror.NotFound("application_not_found").WithCause(err)
+		}
+		if applicantUserID != userID {
+			return apierror.Forbidden("forbidden")
+		}
+		if status != applicationStatusPending {
+			return apierror.Conflict("application_not_withdrawable")
+		}
+
+		if _, err := h.db.Pool.Exec(c.Context(), `
+UPDATE issue_applications SET status = $1, updated_at = now() WHERE id = $2
+`, applicationStatusWithdrawn, applicationID); err != nil {
+			return apierror.Internal("application_withdraw_failed").WithCause(err)
+		}
+
+		var fullName string
+		var installationID *string
+		if err := h.db.Pool.QueryRow(c.Context(), `
+SELECT github_full_name, github_app_installation_id FROM projects WHERE id = $1
+`, projectID).Scan(&fullName, &installationID); err == nil && installationID != nil && strings.TrimSpace(*installationID) != "" &&
+			strings.TrimSpace(h.cfg.GitHubAppID) != "" && strings.TrimSpace(h.cfg.GitHubAppPrivateKey) != "" {
+			appClient, errApp := github.NewGitHubAppClient(h.cfg.GitHubAppID, h.cfg.GitHubAppPrivateKey)
+			if errApp == nil {
+				if token, errTok := appClient.GetInstallationToken(c.Context(), strings.TrimSpace(*installationID)); errTok == nil {
+					if delErr := github.NewClient().DeleteIssueComment(c.Context(), token, fullName, githubCommentID); delErr != nil {
+						slog.Warn("failed to delete github comment for withdrawn application",
+							"project_id", projectID.String(), "application_id", applicationID.String(), "error", delErr)
+					}
+				}
+			}
+		}
+
+		return c.Status(fiber.StatusOK).JSON(withdrawApplicationResponse{OK: true})
+	}
+}
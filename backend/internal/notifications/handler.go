@@ -0,0 +1,138 @@
+package notifications
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/jagadeesh/grainlify/backend/internal/apierror"
+	"github.com/jagadeesh/grainlify/backend/internal/auth"
+	"github.com/jagadeesh/grainlify/backend/internal/config"
+	"github.com/jagadeesh/grainlify/backend/internal/db"
+)
+
+type NotificationsHandler struct {
+	cfg config.Config
+	db  *db.DB
+}
+
+func NewNotificationsHandler(cfg config.Config, d *db.DB) *NotificationsHandler {
+	return &NotificationsHandler{cfg: cfg, db: d}
+}
+
+const defaultListTake = 20
+
+// List returns the caller's notifications, newest first. Pass ?past=true to
+// include already-read notifications; otherwise only unread ones are returned.
+func (h *NotificationsHandler) List() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if h.db == nil || h.db.Pool == nil {
+			return apierror.ServiceUnavailable("db_not_configured")
+		}
+
+		userIDStr, _ := c.Locals(auth.LocalUserID).(string)
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return apierror.Unauthorized("invalid_user")
+		}
+
+		past := c.Query("past") == "true"
+		take := c.QueryInt("take", defaultListTake)
+		if take <= 0 || take > 100 {
+			take = defaultListTake
+		}
+		offset := c.QueryInt("offset", 0)
+		if offset < 0 {
+			offset = 0
+		}
+
+		rows, err := h.db.Pool.Query(c.Context(), `
+SELECT id, recipient_user_id, kind, payload, read_at, created_at
+FROM notifications
+WHERE recipient_user_id = $1 AND ($2 OR read_at IS NULL)
+ORDER BY created_at DESC
+LIMIT $3 OFFSET $4
+`, userID, past, take, offset)
+		if err != nil {
+			return apierror.Internal("notifications_query_failed").WithCause(err)
+		}
+		defer rows.Close()
+
+		data := make([]Notification, 0, take)
+		for rows.Next() {
+			var n Notification
+			if err := rows.Scan(&n.ID, &n.RecipientUserID, &n.Kind, &n.Payload, &n.ReadAt, &n.CreatedAt); err != nil {
+				return apierror.Internal("notifications_scan_failed").WithCause(err)
+			}
+			data = append(data, n)
+		}
+
+		var count int
+		if err := h.db.Pool.QueryRow(c.Context(), `
+SELECT count(*) FROM notifications WHERE recipient_user_id = $1 AND ($2 OR read_at IS NULL)
+`, userID, past).Scan(&count); err != nil {
+			return apierror.Internal("notifications_count_failed").WithCause(err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"count": count, "data": data})
+	}
+}
+
+// MarkRead marks a single notification owned by the caller as read.
+func (h *NotificationsHandler) MarkRead() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if h.db == nil || h.db.Pool == nil {
+			return apierror.ServiceUnavailable("db_not_configured")
+		}
+
+		userIDStr, _ := c.Locals(auth.LocalUserID).(string)
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return apierror.Unauthorized("invalid_user")
+		}
+
+		notificationID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return apierror.BadRequest("invalid_notification_id")
+		}
+
+		// No read_at IS NULL predicate here: marking an already-read notification
+		// as read is a no-op, not a 404, so retries stay idempotent. COALESCE
+		// keeps the original read_at instead of bumping it on a repeat call.
+		tag, err := h.db.Pool.Exec(c.Context(), `
+UPDATE notifications SET read_at = COALESCE(read_at, now())
+WHERE id = $1 AND recipient_user_id = $2
+`, notificationID, userID)
+		if err != nil {
+			return apierror.Internal("notification_update_failed").WithCause(err)
+		}
+		if tag.RowsAffected() == 0 {
+			return apierror.NotFound("notification_not_found")
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"ok": true})
+	}
+}
+
+// MarkAllRead marks every unread notification owned by the caller as read.
+func (h *NotificationsHandler) MarkAllRead() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if h.db == nil || h.db.Pool == nil {
+			return apierror.ServiceUnavailable("db_not_configured")
+		}
+
+		userIDStr, _ := c.Locals(auth.LocalUserID).(string)
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return apierror.Unauthorized("invalid_user")
+		}
+
+		if _, err := h.db.Pool.Exec(c.Context(), `
+UPDATE notifications SET read_at = now()
+WHERE recipient_user_id = $1 AND read_at IS NULL
+`, userID); err != nil {
+			return apierror.Internal("notifications_update_failed").WithCause(err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"ok": true})
+	}
+}
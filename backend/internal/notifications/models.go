@@ -0,0 +1,46 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Kinds of notifications the app currently emits. Payload shape is
+// kind-specific; consumers should switch on Kind before decoding Payload.
+const (
+	KindApplicationSubmitted = "application_submitted"
+	KindApplicationAccepted  = "application_accepted"
+)
+
+type Notification struct {
+	ID              uuid.UUID       `json:"id"`
+	RecipientUserID uuid.UUID       `json:"recipient_user_id"`
+	Kind            string          `json:"kind"`
+	Payload         json.RawMessage `json:"payload"`
+	ReadAt          *time.Time      `json:"read_at"`
+	CreatedAt       time.Time       `json:"created_at"`
+}
+
+// execer is satisfied by both *pgxpool.Pool and pgx.Tx, so Enqueue can run
+// either standalone or as part of a caller's transaction.
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// Enqueue inserts a notification for recipientUserID. payload is marshaled
+// to JSON; pass a struct or map with whatever fields the kind needs.
+func Enqueue(ctx context.Context, q execer, recipientUserID uuid.UUID, kind string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = q.Exec(ctx, `
+INSERT INTO notifications (recipient_user_id, kind, payload)
+VALUES ($1, $2, $3::jsonb)
+`, recipientUserID, kind, body)
+	return err
+}